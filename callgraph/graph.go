@@ -0,0 +1,151 @@
+// Package callgraph builds a whole-program call graph with SSA and answers
+// reachability queries over it. It uses CHA (Class Hierarchy Analysis)
+// rather than RTA or pointer analysis: CHA is less precise about which
+// concrete types actually reach a call site, but it scales to large
+// programs without a points-to analysis pass.
+package callgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/types"
+
+	xcallgraph "golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/callgraph/cha"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// EdgeKind distinguishes a call through an interface method, which can only
+// be resolved at runtime, from every other call. Calls through a function
+// value are tagged EdgeKindStatic too.
+type EdgeKind int
+
+const (
+	EdgeKindStatic EdgeKind = iota
+	EdgeKindDynamic
+)
+
+func (k EdgeKind) String() string {
+	if k == EdgeKindDynamic {
+		return "dynamic"
+	}
+	return "static"
+}
+
+// MarshalJSON encodes an EdgeKind as its string form ("static" or
+// "dynamic") instead of its underlying int, so /api/callers responses match
+// the format Snapshot already uses.
+func (k EdgeKind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.String())
+}
+
+// Node is one function in the call graph, identified by a stable ID built
+// from its package path and, for methods, its receiver type.
+type Node struct {
+	ID string
+	fn *ssa.Function
+}
+
+// Edge is a single call from Caller to Callee.
+type Edge struct {
+	Caller *Node
+	Callee *Node
+	Kind   EdgeKind
+}
+
+// Graph is a whole-program call graph. Interface calls are resolved to
+// every concrete type satisfying the interface and tagged EdgeKindDynamic
+// so UI layers can tell them apart from direct calls.
+type Graph struct {
+	nodes map[string]*Node
+	edges map[string][]Edge // keyed by caller ID
+}
+
+// Build constructs a whole-program call graph for pkgs (as loaded by
+// golang.org/x/tools/go/packages) using CHA.
+func Build(pkgs []*packages.Package) (*Graph, error) {
+	prog, _ := ssautil.AllPackages(pkgs, 0)
+	prog.Build()
+
+	cg := cha.CallGraph(prog)
+	cg.DeleteSyntheticNodes()
+
+	g := &Graph{
+		nodes: make(map[string]*Node),
+		edges: make(map[string][]Edge),
+	}
+
+	for fn, node := range cg.Nodes {
+		if fn == nil {
+			continue
+		}
+		caller := g.nodeFor(fn)
+		for _, e := range node.Out {
+			if e.Callee.Func == nil {
+				continue
+			}
+			callee := g.nodeFor(e.Callee.Func)
+			g.edges[caller.ID] = append(g.edges[caller.ID], Edge{
+				Caller: caller,
+				Callee: callee,
+				Kind:   edgeKind(e),
+			})
+		}
+	}
+
+	return g, nil
+}
+
+func (g *Graph) nodeFor(fn *ssa.Function) *Node {
+	n := g.nodeForID(nodeID(fn))
+	n.fn = fn
+	return n
+}
+
+// nodeForID returns the node named id, creating it if this is the first
+// time it's been seen.
+func (g *Graph) nodeForID(id string) *Node {
+	if n, ok := g.nodes[id]; ok {
+		return n
+	}
+	n := &Node{ID: id}
+	g.nodes[id] = n
+	return n
+}
+
+func nodeID(fn *ssa.Function) string {
+	if fn.Pkg == nil {
+		return fn.String()
+	}
+	pkgPath := fn.Pkg.Pkg.Path()
+	if recv := fn.Signature.Recv(); recv != nil {
+		return fmt.Sprintf("%s#%s.%s", pkgPath, receiverTypeName(recv.Type()), fn.Name())
+	}
+	return fmt.Sprintf("%s#%s", pkgPath, fn.Name())
+}
+
+// receiverTypeName returns just a method receiver's type name (e.g.
+// "Graph"), stripping the pointer and package qualification that
+// recv.Type().String() would otherwise include. web.symbolID builds the
+// same ID from walker's AST-derived GoMethod.Receiver, which is always the
+// bare name as written in source (walker already strips the '*' itself) --
+// the two must agree exactly or /api/callers can never match a web symbol
+// ID to a call graph node.
+func receiverTypeName(t types.Type) string {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if named, ok := t.(*types.Named); ok {
+		return named.Obj().Name()
+	}
+	return t.String()
+}
+
+func edgeKind(e *xcallgraph.Edge) EdgeKind {
+	if e.Site != nil && e.Site.Common().IsInvoke() {
+		return EdgeKindDynamic
+	}
+	return EdgeKindStatic
+}
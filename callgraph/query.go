@@ -0,0 +1,95 @@
+package callgraph
+
+// DefaultMaxDepth bounds Paths when no depth limit is supplied, so a cyclic
+// graph cannot make the search run away.
+const DefaultMaxDepth = 20
+
+// Callers returns every edge whose Callee is the node named id.
+func (g *Graph) Callers(id string) []Edge {
+	var out []Edge
+	for _, edges := range g.edges {
+		for _, e := range edges {
+			if e.Callee.ID == id {
+				out = append(out, e)
+			}
+		}
+	}
+	return out
+}
+
+// Callees returns every edge whose Caller is the node named id.
+func (g *Graph) Callees(id string) []Edge {
+	return g.edges[id]
+}
+
+// ReachableFrom returns every node reachable from the node named entry,
+// including entry itself, via a breadth-first search over call edges.
+// Nodes are deduplicated by ID so a cycle is visited only once.
+func (g *Graph) ReachableFrom(entry string) []*Node {
+	start, ok := g.nodes[entry]
+	if !ok {
+		return nil
+	}
+
+	seen := map[string]bool{start.ID: true}
+	queue := []*Node{start}
+	var order []*Node
+
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		order = append(order, n)
+		for _, e := range g.edges[n.ID] {
+			if !seen[e.Callee.ID] {
+				seen[e.Callee.ID] = true
+				queue = append(queue, e.Callee)
+			}
+		}
+	}
+	return order
+}
+
+// Paths returns every simple path from the node named from to the node
+// named to, each at most maxDepth edges long. maxDepth <= 0 uses
+// DefaultMaxDepth. The search tracks visited nodes per path so a cycle
+// cannot loop it forever.
+func (g *Graph) Paths(from, to string, maxDepth int) [][]*Node {
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxDepth
+	}
+
+	start, ok := g.nodes[from]
+	if !ok {
+		return nil
+	}
+	if _, ok := g.nodes[to]; !ok {
+		return nil
+	}
+
+	var paths [][]*Node
+	visited := map[string]bool{start.ID: true}
+
+	var walk func(n *Node, path []*Node)
+	walk = func(n *Node, path []*Node) {
+		if n.ID == to {
+			found := make([]*Node, len(path))
+			copy(found, path)
+			paths = append(paths, found)
+			return
+		}
+		if len(path)-1 >= maxDepth {
+			return
+		}
+		for _, e := range g.edges[n.ID] {
+			if visited[e.Callee.ID] {
+				continue
+			}
+			visited[e.Callee.ID] = true
+			walk(e.Callee, append(path, e.Callee))
+			delete(visited, e.Callee.ID)
+		}
+	}
+
+	walk(start, []*Node{start})
+	return paths
+}
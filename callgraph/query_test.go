@@ -0,0 +1,67 @@
+package callgraph
+
+import "testing"
+
+func newTestGraph(edges map[string][]string) *Graph {
+	g := &Graph{nodes: map[string]*Node{}, edges: map[string][]Edge{}}
+	node := func(id string) *Node {
+		if n, ok := g.nodes[id]; ok {
+			return n
+		}
+		n := &Node{ID: id}
+		g.nodes[id] = n
+		return n
+	}
+	for caller, callees := range edges {
+		c := node(caller)
+		for _, callee := range callees {
+			g.edges[c.ID] = append(g.edges[c.ID], Edge{Caller: c, Callee: node(callee), Kind: EdgeKindStatic})
+		}
+	}
+	return g
+}
+
+func TestReachableFrom(t *testing.T) {
+	g := newTestGraph(map[string][]string{
+		"a": {"b", "c"},
+		"b": {"d"},
+		"c": {"d"},
+	})
+
+	got := g.ReachableFrom("a")
+	if len(got) != 4 {
+		t.Fatalf("expected 4 reachable nodes, got %d: %v", len(got), got)
+	}
+}
+
+func TestPathsRespectsMaxDepth(t *testing.T) {
+	g := newTestGraph(map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"d"},
+	})
+
+	if paths := g.Paths("a", "d", 2); len(paths) != 0 {
+		t.Errorf("expected no paths within depth 2, got %v", paths)
+	}
+	if paths := g.Paths("a", "d", 3); len(paths) != 1 {
+		t.Errorf("expected 1 path within depth 3, got %v", paths)
+	}
+}
+
+func TestCallersAndCallees(t *testing.T) {
+	g := newTestGraph(map[string][]string{
+		"a": {"b"},
+		"c": {"b"},
+	})
+
+	callers := g.Callers("b")
+	if len(callers) != 2 {
+		t.Fatalf("expected 2 callers of b, got %d", len(callers))
+	}
+
+	callees := g.Callees("a")
+	if len(callees) != 1 || callees[0].Callee.ID != "b" {
+		t.Fatalf("expected a to call b, got %+v", callees)
+	}
+}
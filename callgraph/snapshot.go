@@ -0,0 +1,69 @@
+package callgraph
+
+import "encoding/json"
+
+// Snapshot is a serializable copy of a Graph's edges, meant to be persisted
+// alongside a schema.Model so codemapper serve can answer "who calls this"
+// queries without re-running SSA on every request.
+type Snapshot struct {
+	Edges []SnapshotEdge `json:"edges"`
+}
+
+// SnapshotEdge is one call edge in a Snapshot.
+type SnapshotEdge struct {
+	Caller string `json:"caller"`
+	Callee string `json:"callee"`
+	Kind   string `json:"kind"`
+}
+
+// Snapshot captures the graph's current edges for persistence.
+func (g *Graph) Snapshot() Snapshot {
+	var snap Snapshot
+	for _, edges := range g.edges {
+		for _, e := range edges {
+			snap.Edges = append(snap.Edges, SnapshotEdge{
+				Caller: e.Caller.ID,
+				Callee: e.Callee.ID,
+				Kind:   e.Kind.String(),
+			})
+		}
+	}
+	return snap
+}
+
+// Marshal serializes a Snapshot as JSON.
+func (s Snapshot) Marshal() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// Load reconstructs a queryable Graph from JSON previously written by
+// Snapshot.Marshal, without re-running SSA.
+func Load(data []byte) (*Graph, error) {
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, err
+	}
+	return FromSnapshot(snap), nil
+}
+
+// FromSnapshot reconstructs a queryable Graph from a Snapshot. The rebuilt
+// Graph answers Callers, Callees, ReachableFrom, and Paths as the original
+// did for every function with at least one call edge. A Snapshot records
+// only edges, so a function that neither calls nor is called is absent:
+// ReachableFrom and Paths return nil for it. Its Nodes also no longer carry
+// the *ssa.Function Build attached, since that isn't part of the persisted
+// form.
+func FromSnapshot(snap Snapshot) *Graph {
+	g := &Graph{nodes: make(map[string]*Node), edges: make(map[string][]Edge)}
+
+	for _, se := range snap.Edges {
+		caller := g.nodeForID(se.Caller)
+		callee := g.nodeForID(se.Callee)
+		kind := EdgeKindStatic
+		if se.Kind == EdgeKindDynamic.String() {
+			kind = EdgeKindDynamic
+		}
+		g.edges[caller.ID] = append(g.edges[caller.ID], Edge{Caller: caller, Callee: callee, Kind: kind})
+	}
+	return g
+}
@@ -0,0 +1,30 @@
+package callgraph
+
+import "testing"
+
+func TestSnapshotLoadRoundTrip(t *testing.T) {
+	g := newTestGraph(map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+	})
+
+	data, err := g.Snapshot().Marshal()
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	loaded, err := Load(data)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if got := loaded.Callees("a"); len(got) != 1 || got[0].Callee.ID != "b" {
+		t.Fatalf("Callees(a) = %+v, want a call to b", got)
+	}
+	if got := loaded.Callers("c"); len(got) != 1 || got[0].Caller.ID != "b" {
+		t.Fatalf("Callers(c) = %+v, want a call from b", got)
+	}
+	if got := loaded.ReachableFrom("a"); len(got) != 3 {
+		t.Fatalf("ReachableFrom(a) = %v, want 3 nodes", got)
+	}
+}
@@ -0,0 +1,16 @@
+// Package sample is a tiny, type-correct fixture used by integration tests
+// that need a real SSA call graph: one exported type with a pointer-receiver
+// method that calls an unexported function.
+package sample
+
+// Greeter builds a greeting through greet.
+type Greeter struct{}
+
+// Hello returns a greeting produced by the unexported greet helper.
+func (g *Greeter) Hello() string {
+	return greet()
+}
+
+func greet() string {
+	return "hello"
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/p1rallels/codemapper/callgraph"
+)
+
+var callgraphCmd = &cobra.Command{
+	Use:   "callgraph",
+	Short: "Work with codemapper's whole-program call graph",
+}
+
+var callgraphBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Build the call graph for --repo and write its snapshot as JSON to stdout",
+	RunE:  runCallgraphBuild,
+}
+
+func init() {
+	callgraphCmd.AddCommand(callgraphBuildCmd)
+	rootCmd.AddCommand(callgraphCmd)
+}
+
+// runCallgraphBuild loads --repo with go/packages, builds its SSA call
+// graph, and writes a Snapshot that `codemapper serve --callgraph` can load
+// later without re-running SSA.
+func runCallgraphBuild(cmd *cobra.Command, args []string) error {
+	pkgs, err := loadSSAPackages(repoPath)
+	if err != nil {
+		return err
+	}
+
+	g, err := callgraph.Build(pkgs)
+	if err != nil {
+		return err
+	}
+
+	data, err := g.Snapshot().Marshal()
+	if err != nil {
+		return err
+	}
+	_, err = cmd.OutOrStdout().Write(append(data, '\n'))
+	return err
+}
+
+func loadSSAPackages(repoPath string) ([]*packages.Package, error) {
+	cfg := &packages.Config{
+		Dir: repoPath,
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+			packages.NeedImports | packages.NeedDeps | packages.NeedTypes |
+			packages.NeedTypesInfo | packages.NeedSyntax,
+	}
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		return nil, fmt.Errorf("callgraph: load %s: %w", repoPath, err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, fmt.Errorf("callgraph: %s has type errors", repoPath)
+	}
+	return pkgs, nil
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/p1rallels/codemapper/walker"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <ref1> <ref2>",
+	Short: "Report added, removed, and changed exported symbols between two git refs",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	ref1, ref2 := args[0], args[1]
+
+	before, err := symbolsAtRef(repoPath, ref1)
+	if err != nil {
+		return fmt.Errorf("diff: %s: %w", ref1, err)
+	}
+	after, err := symbolsAtRef(repoPath, ref2)
+	if err != nil {
+		return fmt.Errorf("diff: %s: %w", ref2, err)
+	}
+
+	added, removed, changed := diffSymbols(before, after)
+
+	out := cmd.OutOrStdout()
+	for _, name := range added {
+		fmt.Fprintf(out, "+ %s\n", name)
+	}
+	for _, name := range removed {
+		fmt.Fprintf(out, "- %s\n", name)
+	}
+	for _, name := range changed {
+		fmt.Fprintf(out, "~ %s\n", name)
+	}
+	return nil
+}
+
+// symbolsAtRef extracts ref's tree (via git archive) into a temporary
+// directory and returns every
+// exported symbol name found there, mapped to a signature string used to
+// detect changes.
+func symbolsAtRef(repoPath, ref string) (map[string]string, error) {
+	dir, err := os.MkdirTemp("", "codemapper-diff-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	archive := exec.Command("git", "-C", repoPath, "archive", ref)
+	extract := exec.Command("tar", "-x", "-C", dir)
+
+	pipe, err := archive.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	extract.Stdin = pipe
+
+	if err := extract.Start(); err != nil {
+		return nil, err
+	}
+	if err := archive.Run(); err != nil {
+		return nil, fmt.Errorf("git archive %s: %w", ref, err)
+	}
+	if err := extract.Wait(); err != nil {
+		return nil, fmt.Errorf("extract %s: %w", ref, err)
+	}
+
+	symbols := make(map[string]string)
+	err = walker.WalkFiles(walker.WalkConfig{}, func(gf *walker.GoFile) error {
+		rel, err := filepath.Rel(dir, gf.Path)
+		if err != nil {
+			rel = gf.Path
+		}
+		for _, t := range gf.Types {
+			if isExported(t.Name) {
+				symbols[symbolKey(rel, t.Name)] = fmt.Sprintf("%+v", t.Fields)
+			}
+		}
+		for _, iface := range gf.Interfaces {
+			if isExported(iface.Name) {
+				symbols[symbolKey(rel, iface.Name)] = fmt.Sprintf("%+v", iface.Methods)
+			}
+		}
+		for _, fn := range gf.Funcs {
+			if isExported(fn.Name) {
+				symbols[symbolKey(rel, fn.Name)] = fn.Signature
+			}
+		}
+		for _, m := range gf.Methods {
+			if isExported(m.Name) {
+				symbols[symbolKey(rel, m.Receiver+"."+m.Name)] = m.Signature
+			}
+		}
+		return nil
+	}, dir)
+	if err != nil {
+		return nil, err
+	}
+	return symbols, nil
+}
+
+func symbolKey(file, name string) string {
+	return file + ":" + name
+}
+
+func isExported(name string) bool {
+	r := []rune(name)
+	return len(r) > 0 && r[0] >= 'A' && r[0] <= 'Z'
+}
+
+// diffSymbols compares two symbol snapshots and returns their names sorted
+// into added, removed, and changed buckets.
+func diffSymbols(before, after map[string]string) (added, removed, changed []string) {
+	for name, sig := range after {
+		prev, ok := before[name]
+		if !ok {
+			added = append(added, name)
+		} else if prev != sig {
+			changed = append(changed, name)
+		}
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(changed)
+	return added, removed, changed
+}
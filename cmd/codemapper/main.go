@@ -0,0 +1,11 @@
+// Command codemapper inventories the types, interfaces, methods, and call
+// edges of a Go module and renders them as a browsable map.
+package main
+
+import "os"
+
+func main() {
+	if err := Execute(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/p1rallels/codemapper/schema"
+	"github.com/p1rallels/codemapper/walker"
+)
+
+var mapFormat string
+
+var mapCmd = &cobra.Command{
+	Use:   "map",
+	Short: "Produce a map of types, interfaces, methods, and functions in --repo",
+	RunE:  runMap,
+}
+
+func init() {
+	mapCmd.Flags().StringVar(&mapFormat, "format", "json", "output format: json, dot, mermaid, or schema")
+	rootCmd.AddCommand(mapCmd)
+}
+
+func runMap(cmd *cobra.Command, args []string) error {
+	pkgs, err := collectPackages(repoPath)
+	if err != nil {
+		return err
+	}
+
+	switch mapFormat {
+	case "json":
+		return printJSON(cmd, pkgs)
+	case "dot":
+		return printDOT(cmd, pkgs)
+	case "mermaid":
+		return printMermaid(cmd, pkgs)
+	case "schema":
+		return printSchema(cmd, pkgs)
+	default:
+		return fmt.Errorf("map: unknown --format %q (want json, dot, mermaid, or schema)", mapFormat)
+	}
+}
+
+// collectPackages walks repoPath and returns every discovered package in a
+// stable, directory-sorted order so repeated runs produce identical output.
+func collectPackages(repoPath string) ([]*walker.GoPackage, error) {
+	var pkgs []*walker.GoPackage
+	err := walker.WalkPackages(walker.WalkConfig{}, func(pkg *walker.GoPackage) error {
+		pkgs = append(pkgs, pkg)
+		return nil
+	}, repoPath)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pkgs, func(i, j int) bool { return pkgs[i].ImportPath < pkgs[j].ImportPath })
+	return pkgs, nil
+}
+
+func printJSON(cmd *cobra.Command, pkgs []*walker.GoPackage) error {
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	return enc.Encode(pkgs)
+}
+
+func printDOT(cmd *cobra.Command, pkgs []*walker.GoPackage) error {
+	out := cmd.OutOrStdout()
+	fmt.Fprintln(out, "digraph codemapper {")
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			for _, t := range f.Types {
+				fmt.Fprintf(out, "  %q [shape=box];\n", t.Name)
+			}
+			for _, m := range f.Methods {
+				fmt.Fprintf(out, "  %q -> %q [label=%q];\n", m.Receiver, m.Receiver, m.Name)
+			}
+		}
+	}
+	fmt.Fprintln(out, "}")
+	return nil
+}
+
+func printSchema(cmd *cobra.Command, pkgs []*walker.GoPackage) error {
+	data, err := schema.Generate(schema.FromPackages(pkgs))
+	if err != nil {
+		return err
+	}
+	_, err = cmd.OutOrStdout().Write(append(data, '\n'))
+	return err
+}
+
+func printMermaid(cmd *cobra.Command, pkgs []*walker.GoPackage) error {
+	out := cmd.OutOrStdout()
+	fmt.Fprintln(out, "classDiagram")
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			for _, t := range f.Types {
+				fmt.Fprintf(out, "  class %s\n", t.Name)
+			}
+			for _, iface := range f.Interfaces {
+				fmt.Fprintf(out, "  class %s {\n", iface.Name)
+				for _, m := range iface.Methods {
+					fmt.Fprintf(out, "    +%s()\n", m.Name)
+				}
+				fmt.Fprintln(out, "  }")
+			}
+			for _, m := range f.Methods {
+				fmt.Fprintf(out, "  %s : +%s()\n", m.Receiver, m.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// repoPath is the path to the Go module to analyze, set via the --repo
+// persistent flag and shared by every subcommand.
+var repoPath string
+
+var rootCmd = &cobra.Command{
+	Use:   "codemapper",
+	Short: "Map the types, interfaces, methods, and call edges of a Go module",
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&repoPath, "repo", ".", "path to the Go module to analyze")
+}
+
+// Execute runs the root command, printing any error it produces to stderr
+// and returning it. main turns a non-nil error into exit status 1.
+func Execute() error {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/p1rallels/codemapper/schema"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Work with codemapper's schema documents",
+}
+
+var schemaDiffCmd = &cobra.Command{
+	Use:   "diff <a.json> <b.json>",
+	Short: "Report added, removed, renamed, and changed symbols between two schema documents",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runSchemaDiff,
+}
+
+func init() {
+	schemaCmd.AddCommand(schemaDiffCmd)
+	rootCmd.AddCommand(schemaCmd)
+}
+
+func runSchemaDiff(cmd *cobra.Command, args []string) error {
+	a, err := loadSchema(args[0])
+	if err != nil {
+		return err
+	}
+	b, err := loadSchema(args[1])
+	if err != nil {
+		return err
+	}
+
+	d := schema.DiffModels(a, b)
+
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
+
+func loadSchema(path string) (*schema.Model, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return schema.Parse(data)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/p1rallels/codemapper/callgraph"
+	"github.com/p1rallels/codemapper/web"
+)
+
+var (
+	servePort      int
+	serveCallgraph string
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Start an HTTP server for browsing the map of --repo",
+	RunE:  runServe,
+}
+
+func init() {
+	serveCmd.Flags().IntVar(&servePort, "port", 8080, "port to listen on")
+	serveCmd.Flags().StringVar(&serveCallgraph, "callgraph", "", "path to a call-graph snapshot written by 'codemapper callgraph build' (optional; lets /api/callers answer without re-running SSA)")
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	var cg *callgraph.Graph
+	if serveCallgraph != "" {
+		var err error
+		cg, err = loadCallgraphSnapshot(serveCallgraph)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "serving %s on :%d\n", repoPath, servePort)
+	return web.StartServer(repoPath, servePort, cg)
+}
+
+func loadCallgraphSnapshot(path string) (*callgraph.Graph, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("serve: read callgraph snapshot: %w", err)
+	}
+	return callgraph.Load(data)
+}
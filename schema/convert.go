@@ -0,0 +1,54 @@
+package schema
+
+import "github.com/p1rallels/codemapper/walker"
+
+// FromPackages converts the walker's in-memory package model into the
+// serializable schema form. This is the only place that needs to know about
+// walker's types, keeping the rest of this package decoupled from the
+// analyzer that produced them.
+func FromPackages(pkgs []*walker.GoPackage) *Model {
+	model := &Model{SchemaVersion: CurrentVersion}
+	for _, pkg := range pkgs {
+		p := Package{ImportPath: pkg.ImportPath, Name: pkg.Name}
+		for _, f := range pkg.Files {
+			for _, t := range f.Types {
+				p.Types = append(p.Types, Type{Name: t.Name, Fields: convertFields(t.Fields)})
+			}
+			for _, iface := range f.Interfaces {
+				p.Interfaces = append(p.Interfaces, Interface{
+					Name:    iface.Name,
+					Methods: convertInterfaceMethods(iface.Methods),
+				})
+			}
+			for _, fn := range f.Funcs {
+				p.Funcs = append(p.Funcs, Func{Name: fn.Name, Signature: fn.Signature})
+			}
+			for _, m := range f.Methods {
+				p.Methods = append(p.Methods, Method{
+					Receiver:      m.Receiver,
+					ReceiverIsPtr: m.ReceiverIsPtr,
+					Name:          m.Name,
+					Signature:     m.Signature,
+				})
+			}
+		}
+		model.Packages = append(model.Packages, p)
+	}
+	return model
+}
+
+func convertFields(fields []walker.GoField) []Field {
+	out := make([]Field, len(fields))
+	for i, f := range fields {
+		out[i] = Field{Name: f.Name, Type: f.Type}
+	}
+	return out
+}
+
+func convertInterfaceMethods(methods []walker.GoInterfaceMethod) []InterfaceMethod {
+	out := make([]InterfaceMethod, len(methods))
+	for i, m := range methods {
+		out[i] = InterfaceMethod{Name: m.Name, Signature: m.Signature}
+	}
+	return out
+}
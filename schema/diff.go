@@ -0,0 +1,120 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Rename pairs a removed symbol with an added one believed to be the same
+// symbol under a new name.
+type Rename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Diff reports the added, removed, renamed, and changed symbols between two
+// schema documents, keyed by "importPath#name".
+type Diff struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Changed []string `json:"changed,omitempty"`
+	Renamed []Rename `json:"renamed,omitempty"`
+}
+
+// DiffModels compares two models symbol-by-symbol. A symbol present in both
+// but with a different shape (fields, method set, or signature) is reported
+// as changed. An added symbol and a removed symbol in the same package with
+// an identical shape are reported as a rename rather than as independent
+// additions and removals.
+func DiffModels(a, b *Model) Diff {
+	before := flatten(a)
+	after := flatten(b)
+
+	var added, removed, changed []string
+	for key, shape := range after {
+		prev, ok := before[key]
+		if !ok {
+			added = append(added, key)
+		} else if prev != shape {
+			changed = append(changed, key)
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+
+	renamed, added, removed := matchRenames(before, after, added, removed)
+
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(changed)
+	sort.Slice(renamed, func(i, j int) bool { return renamed[i].From < renamed[j].From })
+
+	return Diff{Added: added, Removed: removed, Changed: changed, Renamed: renamed}
+}
+
+// matchRenames pairs each removed symbol with an added symbol of identical
+// shape in the same package, on the theory that a symbol whose shape didn't
+// change but whose name did is a rename rather than an unrelated add and
+// remove. Matching is first-fit and best-effort: it can mispair two
+// same-shaped but otherwise-unrelated symbols (e.g. two empty structs)
+// added and removed in the same package.
+func matchRenames(before, after map[string]string, added, removed []string) (renamed []Rename, restAdded, restRemoved []string) {
+	usedAdded := make(map[string]bool)
+
+	for _, from := range removed {
+		pkg, shape := packageOf(from), before[from]
+		match := ""
+		for _, to := range added {
+			if usedAdded[to] || packageOf(to) != pkg || after[to] != shape {
+				continue
+			}
+			match = to
+			break
+		}
+		if match == "" {
+			restRemoved = append(restRemoved, from)
+			continue
+		}
+		usedAdded[match] = true
+		renamed = append(renamed, Rename{From: from, To: match})
+	}
+
+	for _, to := range added {
+		if !usedAdded[to] {
+			restAdded = append(restAdded, to)
+		}
+	}
+	return renamed, restAdded, restRemoved
+}
+
+func packageOf(key string) string {
+	if i := strings.Index(key, "#"); i >= 0 {
+		return key[:i]
+	}
+	return key
+}
+
+// flatten reduces a model to a map of symbol key to a string describing its
+// shape, so two models can be compared with ordinary map diffing.
+func flatten(m *Model) map[string]string {
+	out := make(map[string]string)
+	for _, pkg := range m.Packages {
+		for _, t := range pkg.Types {
+			out[pkg.ImportPath+"#"+t.Name] = fmt.Sprintf("%+v", t.Fields)
+		}
+		for _, iface := range pkg.Interfaces {
+			out[pkg.ImportPath+"#"+iface.Name] = fmt.Sprintf("%+v", iface.Methods)
+		}
+		for _, fn := range pkg.Funcs {
+			out[pkg.ImportPath+"#"+fn.Name] = fn.Signature
+		}
+		for _, m := range pkg.Methods {
+			out[pkg.ImportPath+"#"+m.Receiver+"."+m.Name] = m.Signature
+		}
+	}
+	return out
+}
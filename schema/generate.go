@@ -0,0 +1,19 @@
+package schema
+
+import "encoding/json"
+
+// Generate serializes model as indented JSON, stamping the current schema
+// version onto it first.
+func Generate(model *Model) ([]byte, error) {
+	model.SchemaVersion = CurrentVersion
+	return json.MarshalIndent(model, "", "  ")
+}
+
+// Parse reads a document previously produced by Generate.
+func Parse(data []byte) (*Model, error) {
+	var model Model
+	if err := json.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
@@ -0,0 +1,66 @@
+// Package schema serializes the parsed symbol model into a stable,
+// versioned JSON representation intended as a source of truth that tooling
+// outside this repository — including tools written in other languages —
+// can consume to regenerate equivalent type stubs, mocks, or documentation
+// without re-parsing Go source itself.
+package schema
+
+// CurrentVersion is the schema version Generate stamps onto every document
+// it writes. Consumers should refuse or migrate documents whose
+// SchemaVersion is newer than they understand.
+const CurrentVersion = 1
+
+// Model is the full serialized symbol graph for a module.
+type Model struct {
+	SchemaVersion int       `json:"schemaVersion"`
+	Packages      []Package `json:"packages"`
+}
+
+// Package is one Go package's parsed shape.
+type Package struct {
+	ImportPath string      `json:"importPath"`
+	Name       string      `json:"name"`
+	Types      []Type      `json:"types,omitempty"`
+	Interfaces []Interface `json:"interfaces,omitempty"`
+	Funcs      []Func      `json:"funcs,omitempty"`
+	Methods    []Method    `json:"methods,omitempty"`
+}
+
+// Field is one struct field, e.g. User.Name string.
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Type is a struct type and its fields, e.g. User{Name, Email, Age}.
+type Type struct {
+	Name   string  `json:"name"`
+	Fields []Field `json:"fields,omitempty"`
+}
+
+// InterfaceMethod is one method an interface requires, e.g. Config.GetPort.
+type InterfaceMethod struct {
+	Name      string `json:"name"`
+	Signature string `json:"signature"`
+}
+
+// Interface is an interface type and its method set.
+type Interface struct {
+	Name    string            `json:"name"`
+	Methods []InterfaceMethod `json:"methods,omitempty"`
+}
+
+// Func is a top-level function with no receiver, e.g. processUser.
+type Func struct {
+	Name      string `json:"name"`
+	Signature string `json:"signature"`
+}
+
+// Method is a function declared with a receiver, e.g. (User).GetName or
+// (*User).UpdateEmail.
+type Method struct {
+	Receiver      string `json:"receiver"`
+	ReceiverIsPtr bool   `json:"receiverIsPtr"`
+	Name          string `json:"name"`
+	Signature     string `json:"signature"`
+}
@@ -0,0 +1,87 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/p1rallels/codemapper/walker"
+)
+
+func parseExample(t *testing.T) *Model {
+	t.Helper()
+	var pkgs []*walker.GoPackage
+	err := walker.WalkPackages(walker.WalkConfig{}, func(pkg *walker.GoPackage) error {
+		pkgs = append(pkgs, pkg)
+		return nil
+	}, "../test_files")
+	if err != nil {
+		t.Fatalf("WalkPackages returned error: %v", err)
+	}
+	return FromPackages(pkgs)
+}
+
+func TestGenerateParseRoundTrip(t *testing.T) {
+	model := parseExample(t)
+
+	data, err := Generate(model)
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+
+	got, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	if got.SchemaVersion != CurrentVersion {
+		t.Errorf("SchemaVersion = %d, want %d", got.SchemaVersion, CurrentVersion)
+	}
+	if len(got.Packages) != len(model.Packages) {
+		t.Fatalf("got %d packages, want %d", len(got.Packages), len(model.Packages))
+	}
+}
+
+func TestDiffModelsDetectsAddedAndChanged(t *testing.T) {
+	before := &Model{Packages: []Package{{
+		ImportPath: "example",
+		Types:      []Type{{Name: "User", Fields: []Field{{Name: "Name", Type: "string"}}}},
+	}}}
+	after := &Model{Packages: []Package{{
+		ImportPath: "example",
+		Types: []Type{
+			{Name: "User", Fields: []Field{{Name: "Name", Type: "string"}, {Name: "Age", Type: "int"}}},
+			{Name: "Account", Fields: nil},
+		},
+	}}}
+
+	d := DiffModels(before, after)
+
+	if len(d.Added) != 1 || d.Added[0] != "example#Account" {
+		t.Errorf("Added = %v, want [example#Account]", d.Added)
+	}
+	if len(d.Changed) != 1 || d.Changed[0] != "example#User" {
+		t.Errorf("Changed = %v, want [example#User]", d.Changed)
+	}
+	if len(d.Removed) != 0 {
+		t.Errorf("Removed = %v, want none", d.Removed)
+	}
+}
+
+func TestDiffModelsDetectsRename(t *testing.T) {
+	before := &Model{Packages: []Package{{
+		ImportPath: "example",
+		Types:      []Type{{Name: "User", Fields: []Field{{Name: "Name", Type: "string"}}}},
+	}}}
+	after := &Model{Packages: []Package{{
+		ImportPath: "example",
+		Types:      []Type{{Name: "Account", Fields: []Field{{Name: "Name", Type: "string"}}}},
+	}}}
+
+	d := DiffModels(before, after)
+
+	if len(d.Renamed) != 1 || d.Renamed[0] != (Rename{From: "example#User", To: "example#Account"}) {
+		t.Errorf("Renamed = %v, want [{example#User example#Account}]", d.Renamed)
+	}
+	if len(d.Added) != 0 || len(d.Removed) != 0 {
+		t.Errorf("expected rename to not also show up as Added/Removed, got added=%v removed=%v", d.Added, d.Removed)
+	}
+}
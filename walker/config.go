@@ -0,0 +1,26 @@
+package walker
+
+// WalkConfig controls how WalkFiles and WalkPackages traverse a tree of Go
+// source looking for files to visit.
+type WalkConfig struct {
+	// Include is a list of glob patterns matched against the file's base name
+	// and full path. A file must match at least one to be visited. A nil or
+	// empty Include matches every file.
+	Include []string
+
+	// Exclude is a list of glob patterns matched the same way as Include. A
+	// file matching any of these is skipped even if it matches Include.
+	Exclude []string
+
+	// IncludeVendor controls whether files under a vendor/ directory are
+	// visited. Defaults to false.
+	IncludeVendor bool
+
+	// IncludeTestdata controls whether files under a testdata/ directory are
+	// visited. Defaults to false.
+	IncludeTestdata bool
+
+	// IncludeTests controls whether _test.go files are visited. Defaults to
+	// false.
+	IncludeTests bool
+}
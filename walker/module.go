@@ -0,0 +1,86 @@
+package walker
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// moduleInfo is the go.mod a directory resolves to: its module path and the
+// absolute directory go.mod lives in.
+type moduleInfo struct {
+	path string
+	root string
+}
+
+// moduleCache memoizes go.mod lookups (keyed by the directory containing
+// go.mod) so that walking a large tree doesn't re-stat the same go.mod once
+// per package directory beneath it.
+type moduleCache struct {
+	byRoot map[string]moduleInfo
+}
+
+func newModuleCache() *moduleCache {
+	return &moduleCache{byRoot: make(map[string]moduleInfo)}
+}
+
+// importPathFor returns the Go import path for dir: the nearest enclosing
+// module's path joined with dir's path relative to that module's root. This
+// is what the Go toolchain itself would call the package, so IDs derived
+// from it line up with the ones golang.org/x/tools/go/packages produces
+// (see callgraph.nodeID). If dir isn't inside a module (no go.mod found
+// above it), dir's absolute path is used as a fallback so callers still get
+// a stable, if not canonical, identifier.
+func (c *moduleCache) importPathFor(dir string) string {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return filepath.ToSlash(dir)
+	}
+
+	mod, ok := c.findModule(absDir)
+	if !ok {
+		return filepath.ToSlash(absDir)
+	}
+
+	rel, err := filepath.Rel(mod.root, absDir)
+	if err != nil || rel == "." {
+		return mod.path
+	}
+	return mod.path + "/" + filepath.ToSlash(rel)
+}
+
+func (c *moduleCache) findModule(dir string) (moduleInfo, bool) {
+	for d := dir; ; {
+		if mod, ok := c.byRoot[d]; ok {
+			return mod, true
+		}
+		if path, ok := readModulePath(filepath.Join(d, "go.mod")); ok {
+			mod := moduleInfo{path: path, root: d}
+			c.byRoot[d] = mod
+			return mod, true
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return moduleInfo{}, false
+		}
+		d = parent
+	}
+}
+
+func readModulePath(goModPath string) (string, bool) {
+	f, err := os.Open(goModPath)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module")), true
+		}
+	}
+	return "", false
+}
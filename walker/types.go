@@ -0,0 +1,65 @@
+package walker
+
+import "go/token"
+
+// GoFile is a single parsed Go source file along with the symbols it
+// declares.
+type GoFile struct {
+	Path       string
+	Package    string
+	Types      []GoType
+	Interfaces []GoInterface
+	Funcs      []GoFunc
+	Methods    []GoMethod
+}
+
+// GoPackage groups every GoFile found in the same directory during a walk.
+type GoPackage struct {
+	ImportPath string
+	Name       string
+	Files      []*GoFile
+}
+
+// GoField is a single field of a struct type.
+type GoField struct {
+	Name string
+	Type string
+}
+
+// GoType describes a struct type and its fields, e.g. User{Name, Email, Age}.
+type GoType struct {
+	Name   string
+	Fields []GoField
+	Pos    token.Position
+}
+
+// GoInterfaceMethod is one method required by an interface, e.g.
+// Config.GetPort.
+type GoInterfaceMethod struct {
+	Name      string
+	Signature string
+}
+
+// GoInterface describes an interface type and the methods it requires.
+type GoInterface struct {
+	Name    string
+	Methods []GoInterfaceMethod
+	Pos     token.Position
+}
+
+// GoFunc is a top-level function with no receiver, e.g. processUser.
+type GoFunc struct {
+	Name      string
+	Signature string
+	Pos       token.Position
+}
+
+// GoMethod is a function declared with a receiver, e.g. (User).GetName or
+// (*User).UpdateEmail. ReceiverIsPtr distinguishes the two.
+type GoMethod struct {
+	Name          string
+	Receiver      string
+	ReceiverIsPtr bool
+	Signature     string
+	Pos           token.Position
+}
@@ -0,0 +1,250 @@
+// Package walker discovers Go source files and streams their parsed symbols
+// to a caller-provided callback, one file (or package) at a time, instead of
+// loading an entire tree into memory up front. This matters for mapping
+// large monorepos, where holding every *ast.File resident at once is
+// wasteful. No *ast.File outlives its own file's parse; WalkPackages does
+// keep every file's extracted symbols until the walk finishes (see its doc).
+package walker
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// WalkFiles discovers Go source files under paths and invokes fn once per
+// file, in the order they are found. paths may be directories or individual
+// files. Traversal stops at the first error returned by fn or encountered
+// while walking.
+func WalkFiles(cfg WalkConfig, fn func(*GoFile) error, paths ...string) error {
+	fset := token.NewFileSet()
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != root && skipDir(path, cfg) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !isGoFile(path, cfg) || !matchesFilters(path, cfg) {
+				return nil
+			}
+			gf, err := parseFile(fset, path)
+			if err != nil {
+				return fmt.Errorf("walker: parse %s: %w", path, err)
+			}
+			return fn(gf)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WalkPackages is WalkFiles grouped by directory: every file found in the
+// same directory is batched into a single GoPackage before fn is called for
+// that directory. GoPackage.ImportPath is the directory's real Go import
+// path (resolved against the nearest enclosing go.mod), not the raw
+// filesystem path, so it lines up with the import paths golang.org/x/tools/
+// go/packages reports for the same directory.
+//
+// filepath.WalkDir visits entries in lexical order, so a package directory
+// containing a nested subpackage does not have its own files visited
+// contiguously (e.g. pkg/a.go, pkg/sub/s.go, pkg/z.go visits a.go, descends
+// into sub, then returns to z.go). Packages are therefore accumulated by
+// directory across the whole walk and only handed to fn once traversal
+// finishes, in the order each directory was first seen.
+func WalkPackages(cfg WalkConfig, fn func(*GoPackage) error, paths ...string) error {
+	var order []string
+	byDir := make(map[string]*GoPackage)
+	mods := newModuleCache()
+
+	err := WalkFiles(cfg, func(gf *GoFile) error {
+		dir := filepath.Dir(gf.Path)
+		pkg, ok := byDir[dir]
+		if !ok {
+			pkg = &GoPackage{ImportPath: mods.importPathFor(dir), Name: gf.Package}
+			byDir[dir] = pkg
+			order = append(order, dir)
+		}
+		pkg.Files = append(pkg.Files, gf)
+		return nil
+	}, paths...)
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range order {
+		if err := fn(byDir[dir]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func skipDir(path string, cfg WalkConfig) bool {
+	base := filepath.Base(path)
+	if base == "vendor" && !cfg.IncludeVendor {
+		return true
+	}
+	if base == "testdata" && !cfg.IncludeTestdata {
+		return true
+	}
+	return strings.HasPrefix(base, ".")
+}
+
+func isGoFile(path string, cfg WalkConfig) bool {
+	if !strings.HasSuffix(path, ".go") {
+		return false
+	}
+	if strings.HasSuffix(path, "_test.go") && !cfg.IncludeTests {
+		return false
+	}
+	return true
+}
+
+func matchesFilters(path string, cfg WalkConfig) bool {
+	if len(cfg.Include) > 0 && !matchesAny(cfg.Include, path) {
+		return false
+	}
+	return !matchesAny(cfg.Exclude, path)
+}
+
+func matchesAny(patterns []string, path string) bool {
+	for _, pat := range patterns {
+		if ok, err := filepath.Match(pat, path); err == nil && ok {
+			return true
+		}
+		if ok, err := filepath.Match(pat, filepath.Base(path)); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+func parseFile(fset *token.FileSet, path string) (*GoFile, error) {
+	astFile, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	gf := &GoFile{Path: path, Package: astFile.Name.Name}
+
+	for _, decl := range astFile.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			collectTypes(fset, d, gf)
+		case *ast.FuncDecl:
+			collectFunc(fset, d, gf)
+		}
+	}
+
+	return gf, nil
+}
+
+func collectTypes(fset *token.FileSet, d *ast.GenDecl, gf *GoFile) {
+	if d.Tok != token.TYPE {
+		return
+	}
+	for _, spec := range d.Specs {
+		ts, ok := spec.(*ast.TypeSpec)
+		if !ok {
+			continue
+		}
+		switch t := ts.Type.(type) {
+		case *ast.StructType:
+			gf.Types = append(gf.Types, GoType{
+				Name:   ts.Name.Name,
+				Fields: structFields(t),
+				Pos:    fset.Position(ts.Pos()),
+			})
+		case *ast.InterfaceType:
+			gf.Interfaces = append(gf.Interfaces, GoInterface{
+				Name:    ts.Name.Name,
+				Methods: interfaceMethods(t),
+				Pos:     fset.Position(ts.Pos()),
+			})
+		}
+	}
+}
+
+func structFields(t *ast.StructType) []GoField {
+	var fields []GoField
+	if t.Fields == nil {
+		return fields
+	}
+	for _, f := range t.Fields.List {
+		typ := exprString(f.Type)
+		if len(f.Names) == 0 {
+			// Embedded field: the type name doubles as the field name.
+			fields = append(fields, GoField{Name: typ, Type: typ})
+			continue
+		}
+		for _, name := range f.Names {
+			fields = append(fields, GoField{Name: name.Name, Type: typ})
+		}
+	}
+	return fields
+}
+
+func interfaceMethods(t *ast.InterfaceType) []GoInterfaceMethod {
+	var methods []GoInterfaceMethod
+	if t.Methods == nil {
+		return methods
+	}
+	for _, m := range t.Methods.List {
+		ft, ok := m.Type.(*ast.FuncType)
+		if !ok || len(m.Names) == 0 {
+			continue
+		}
+		methods = append(methods, GoInterfaceMethod{
+			Name:      m.Names[0].Name,
+			Signature: exprString(ft),
+		})
+	}
+	return methods
+}
+
+func collectFunc(fset *token.FileSet, d *ast.FuncDecl, gf *GoFile) {
+	sig := exprString(d.Type)
+	if d.Recv == nil || len(d.Recv.List) == 0 {
+		gf.Funcs = append(gf.Funcs, GoFunc{
+			Name:      d.Name.Name,
+			Signature: sig,
+			Pos:       fset.Position(d.Pos()),
+		})
+		return
+	}
+
+	recv := d.Recv.List[0].Type
+	isPtr := false
+	if star, ok := recv.(*ast.StarExpr); ok {
+		isPtr = true
+		recv = star.X
+	}
+
+	gf.Methods = append(gf.Methods, GoMethod{
+		Name:          d.Name.Name,
+		Receiver:      exprString(recv),
+		ReceiverIsPtr: isPtr,
+		Signature:     sig,
+		Pos:           fset.Position(d.Pos()),
+	})
+}
+
+func exprString(expr ast.Expr) string {
+	var sb strings.Builder
+	if err := printer.Fprint(&sb, token.NewFileSet(), expr); err != nil {
+		return fmt.Sprintf("%T", expr)
+	}
+	return sb.String()
+}
@@ -0,0 +1,156 @@
+package walker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWalkFilesFindsExampleSymbols(t *testing.T) {
+	var files []*GoFile
+	err := WalkFiles(WalkConfig{}, func(gf *GoFile) error {
+		files = append(files, gf)
+		return nil
+	}, "../test_files/example.go")
+	if err != nil {
+		t.Fatalf("WalkFiles returned error: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(files))
+	}
+
+	gf := files[0]
+	if len(gf.Types) != 1 || gf.Types[0].Name != "User" {
+		t.Fatalf("expected User type, got %+v", gf.Types)
+	}
+	if len(gf.Interfaces) != 1 || gf.Interfaces[0].Name != "Config" {
+		t.Fatalf("expected Config interface, got %+v", gf.Interfaces)
+	}
+
+	if !containsFunc(gf.Funcs, "processUser") {
+		t.Errorf("expected processUser among funcs, got %+v", gf.Funcs)
+	}
+	if !containsMethod(gf.Methods, "GetName", false) {
+		t.Errorf("expected value-receiver GetName among methods, got %+v", gf.Methods)
+	}
+	if !containsMethod(gf.Methods, "UpdateEmail", true) {
+		t.Errorf("expected pointer-receiver UpdateEmail among methods, got %+v", gf.Methods)
+	}
+}
+
+func TestWalkPackagesGroupsByDirectory(t *testing.T) {
+	var pkgs []*GoPackage
+	err := WalkPackages(WalkConfig{}, func(pkg *GoPackage) error {
+		pkgs = append(pkgs, pkg)
+		return nil
+	}, "../test_files")
+	if err != nil {
+		t.Fatalf("WalkPackages returned error: %v", err)
+	}
+	if len(pkgs) != 1 {
+		t.Fatalf("expected 1 package, got %d", len(pkgs))
+	}
+	if len(pkgs[0].Files) != 1 {
+		t.Fatalf("expected 1 file in package, got %d", len(pkgs[0].Files))
+	}
+}
+
+// TestWalkPackagesReassemblesInterleavedSiblings guards against grouping by
+// "did the directory change since the last file", which falls apart when a
+// package directory has a nested subpackage alphabetically between two of
+// its own files: pkg/a.go, pkg/sub/s.go, pkg/z.go visits a.go, descends into
+// sub, then returns to z.go, so a naive flush-on-change would split pkg into
+// two separate GoPackage entries instead of one with both files.
+func TestWalkPackagesReassemblesInterleavedSiblings(t *testing.T) {
+	root := t.TempDir()
+	writeGoFile(t, filepath.Join(root, "a.go"), "pkg")
+	writeGoFile(t, filepath.Join(root, "sub", "s.go"), "sub")
+	writeGoFile(t, filepath.Join(root, "z.go"), "pkg")
+
+	var pkgs []*GoPackage
+	err := WalkPackages(WalkConfig{}, func(pkg *GoPackage) error {
+		pkgs = append(pkgs, pkg)
+		return nil
+	}, root)
+	if err != nil {
+		t.Fatalf("WalkPackages returned error: %v", err)
+	}
+
+	var rootPkgs []*GoPackage
+	for _, pkg := range pkgs {
+		if pkg.ImportPath == root {
+			rootPkgs = append(rootPkgs, pkg)
+		}
+	}
+	if len(rootPkgs) != 1 {
+		t.Fatalf("expected 1 package for %s, got %d: %+v", root, len(rootPkgs), rootPkgs)
+	}
+	if len(rootPkgs[0].Files) != 2 {
+		t.Fatalf("expected 2 files in %s, got %d", root, len(rootPkgs[0].Files))
+	}
+}
+
+// TestWalkPackagesImportPathUsesGoMod guards against GoPackage.ImportPath
+// regressing to a raw filesystem path: it must match what
+// golang.org/x/tools/go/packages (and so callgraph.nodeID) would call the
+// same directory, or IDs computed from it can never line up with the call
+// graph's.
+func TestWalkPackagesImportPathUsesGoMod(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/mod\n\ngo 1.21\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile(go.mod): %v", err)
+	}
+	writeGoFile(t, filepath.Join(root, "a.go"), "mod")
+	writeGoFile(t, filepath.Join(root, "sub", "s.go"), "sub")
+
+	var pkgs []*GoPackage
+	err := WalkPackages(WalkConfig{}, func(pkg *GoPackage) error {
+		pkgs = append(pkgs, pkg)
+		return nil
+	}, root)
+	if err != nil {
+		t.Fatalf("WalkPackages returned error: %v", err)
+	}
+
+	want := map[string]bool{"example.com/mod": false, "example.com/mod/sub": false}
+	for _, pkg := range pkgs {
+		if _, ok := want[pkg.ImportPath]; !ok {
+			t.Fatalf("unexpected ImportPath %q", pkg.ImportPath)
+		}
+		want[pkg.ImportPath] = true
+	}
+	for importPath, found := range want {
+		if !found {
+			t.Errorf("expected a package with ImportPath %q, got %+v", importPath, pkgs)
+		}
+	}
+}
+
+func writeGoFile(t *testing.T, path, pkgName string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("MkdirAll(%s): %v", filepath.Dir(path), err)
+	}
+	src := "package " + pkgName + "\n"
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatalf("WriteFile(%s): %v", path, err)
+	}
+}
+
+func containsFunc(funcs []GoFunc, name string) bool {
+	for _, f := range funcs {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func containsMethod(methods []GoMethod, name string, ptr bool) bool {
+	for _, m := range methods {
+		if m.Name == name && m.ReceiverIsPtr == ptr {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package web
+
+import (
+	"embed"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+//go:embed content/static
+var embeddedStatic embed.FS
+
+// DevMode serves static assets and templates straight off disk instead of
+// from the embedded copy, so contributors can iterate on them without
+// rebuilding the binary. Set CODEMAPPER_DEV to any non-empty value to enable
+// it.
+var DevMode = os.Getenv("CODEMAPPER_DEV") != ""
+
+var cachedTemplates = template.Must(template.ParseFS(embeddedStatic, "content/static/templates/*.html"))
+
+// currentTemplates returns the template set handlers should render from. In
+// DevMode it re-parses content/static/templates from disk on every call, so
+// edits show up on reload without a rebuild, matching the disk-backed
+// behavior of assetFS. Otherwise it reuses the embedded set parsed once at
+// startup.
+func currentTemplates() (*template.Template, error) {
+	if !DevMode {
+		return cachedTemplates, nil
+	}
+	return template.ParseGlob(filepath.Join("web", "content", "static", "templates", "*.html"))
+}
+
+// assetFS returns the http.FileSystem static assets are served from,
+// switching between the embedded copy and disk based on DevMode.
+func assetFS() http.FileSystem {
+	if DevMode {
+		return http.Dir(filepath.Join("web", "content", "static"))
+	}
+	sub, err := fs.Sub(embeddedStatic, "content/static")
+	if err != nil {
+		panic(err)
+	}
+	return http.FS(sub)
+}
+
+// getFullPath resolves a request path (already stripped of its mount
+// prefix) to a path inside the static filesystem, defaulting an empty path
+// to index.html the way http.FileServer does for directory roots.
+func getFullPath(urlPath string) string {
+	urlPath = strings.TrimPrefix(urlPath, "/")
+	if urlPath == "" {
+		return "index.html"
+	}
+	return urlPath
+}
+
+func staticHandler() http.Handler {
+	fsys := assetFS()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := getFullPath(r.URL.Path)
+		f, err := fsys.Open(name)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		defer f.Close()
+		http.ServeContent(w, r, name, time.Time{}, f)
+	})
+}
@@ -0,0 +1,85 @@
+package web
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/p1rallels/codemapper/callgraph"
+	"github.com/p1rallels/codemapper/walker"
+)
+
+// TestHandleAPICallersResolvesRealCallgraphIDs builds a real SSA call graph
+// and a real walker-based symbol index over the same fixture package and
+// checks that /api/callers resolves a known call through it. Each package's
+// own unit tests use synthetic IDs and can't catch web's symbol IDs and
+// callgraph's node IDs drifting into incompatible formats; this does.
+func TestHandleAPICallersResolvesRealCallgraphIDs(t *testing.T) {
+	const fixtureDir = "../callgraph/testdata/sample"
+
+	cfg := &packages.Config{
+		Dir: fixtureDir,
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+			packages.NeedImports | packages.NeedDeps | packages.NeedTypes |
+			packages.NeedTypesInfo | packages.NeedSyntax,
+	}
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		t.Fatalf("packages.Load: %v", err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		t.Fatal("fixture package has type errors")
+	}
+
+	g, err := callgraph.Build(pkgs)
+	if err != nil {
+		t.Fatalf("callgraph.Build: %v", err)
+	}
+
+	var walkerPkgs []*walker.GoPackage
+	err = walker.WalkPackages(walker.WalkConfig{}, func(pkg *walker.GoPackage) error {
+		walkerPkgs = append(walkerPkgs, pkg)
+		return nil
+	}, fixtureDir)
+	if err != nil {
+		t.Fatalf("WalkPackages: %v", err)
+	}
+	if len(walkerPkgs) != 1 {
+		t.Fatalf("expected 1 walker package, got %d", len(walkerPkgs))
+	}
+
+	srv := &server{pkgs: walkerPkgs, index: indexSymbols(walkerPkgs), callgraph: g}
+
+	greetID := walkerPkgs[0].ImportPath + "#greet"
+	if _, ok := srv.index[greetID]; !ok {
+		t.Fatalf("expected %s in web's symbol index, got %v", greetID, indexKeys(srv.index))
+	}
+
+	req := httptest.NewRequest("GET", "/api/callers/"+url.PathEscape(greetID), nil)
+	rec := httptest.NewRecorder()
+	srv.handleAPICallers(rec, req)
+
+	if rec.Code != 200 {
+		t.Fatalf("handleAPICallers status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	body := rec.Body.String()
+	if body == "null\n" || body == "[]\n" {
+		t.Fatalf("expected non-empty callers for %s, got %s", greetID, body)
+	}
+
+	wantCaller := walkerPkgs[0].ImportPath + "#Greeter.Hello"
+	if !strings.Contains(body, wantCaller) {
+		t.Fatalf("expected caller %s in response, got %s", wantCaller, body)
+	}
+}
+
+func indexKeys(m map[string]*symbol) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,110 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+func (s *server) registerRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/", s.handleIndex)
+	mux.HandleFunc("/pkg/", s.handlePackage)
+	mux.HandleFunc("/search", s.handleSearch)
+	mux.HandleFunc("/api/symbols", s.handleAPISymbols)
+	mux.HandleFunc("/api/symbol/", s.handleAPISymbol)
+	mux.HandleFunc("/api/callers/", s.handleAPICallers)
+	mux.Handle("/static/", http.StripPrefix("/static/", staticHandler()))
+}
+
+func (s *server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	data := struct{ Packages []*symbol }{Packages: s.sortedSymbols()}
+	tmpl, err := currentTemplates()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := tmpl.ExecuteTemplate(w, "index.html", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *server) handlePackage(w http.ResponseWriter, r *http.Request) {
+	importPath := strings.TrimPrefix(r.URL.Path, "/pkg/")
+	for _, pkg := range s.pkgs {
+		if pkg.ImportPath != importPath {
+			continue
+		}
+		tmpl, err := currentTemplates()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if err := tmpl.ExecuteTemplate(w, "package.html", pkg); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	http.NotFound(w, r)
+}
+
+func (s *server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	q := strings.ToLower(r.URL.Query().Get("q"))
+	var matches []*symbol
+	for _, sym := range s.index {
+		if q == "" || strings.Contains(strings.ToLower(sym.Name), q) {
+			matches = append(matches, sym)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Name < matches[j].Name })
+	writeJSON(w, matches)
+}
+
+func (s *server) handleAPISymbols(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.pkgs)
+}
+
+func (s *server) handleAPISymbol(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/symbol/")
+	sym, ok := s.index[id]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, sym)
+}
+
+// handleAPICallers reports who calls the symbol named by id, using the
+// *callgraph.Graph passed to StartServer. If the server was started without
+// one (no --callgraph snapshot), it returns an empty edge list rather than
+// a guess.
+func (s *server) handleAPICallers(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/callers/")
+	if _, ok := s.index[id]; !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if s.callgraph == nil {
+		writeJSON(w, []struct{}{})
+		return
+	}
+	writeJSON(w, s.callgraph.Callers(id))
+}
+
+func (s *server) sortedSymbols() []*symbol {
+	syms := make([]*symbol, 0, len(s.index))
+	for _, sym := range s.index {
+		syms = append(syms, sym)
+	}
+	sort.Slice(syms, func(i, j int) bool { return syms[i].ID < syms[j].ID })
+	return syms
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,53 @@
+// Package web renders a parsed Go module as a browsable set of HTML pages
+// plus a small JSON API, so contributors can explore a codebase's types,
+// interfaces, methods, and functions without reading the source directly.
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/p1rallels/codemapper/callgraph"
+	"github.com/p1rallels/codemapper/walker"
+)
+
+// StartServer parses repoPath and serves the resulting symbol graph over
+// HTTP on port. cg is optional: when non-nil, /api/callers/{id} answers
+// from it instead of reporting no callers; pass the result of
+// callgraph.Load on a snapshot written by 'codemapper callgraph build' to
+// avoid re-running SSA on every server start. It blocks until the server
+// stops or returns an error.
+func StartServer(repoPath string, port int, cg *callgraph.Graph) error {
+	pkgs, err := loadPackages(repoPath)
+	if err != nil {
+		return err
+	}
+
+	srv := &server{pkgs: pkgs, index: indexSymbols(pkgs), callgraph: cg}
+	mux := http.NewServeMux()
+	srv.registerRoutes(mux)
+
+	addr := fmt.Sprintf(":%d", port)
+	return http.ListenAndServe(addr, mux)
+}
+
+// server holds the parsed model a running instance renders pages from.
+type server struct {
+	pkgs      []*walker.GoPackage
+	index     map[string]*symbol
+	callgraph *callgraph.Graph
+}
+
+func loadPackages(repoPath string) ([]*walker.GoPackage, error) {
+	var pkgs []*walker.GoPackage
+	err := walker.WalkPackages(walker.WalkConfig{}, func(pkg *walker.GoPackage) error {
+		pkgs = append(pkgs, pkg)
+		return nil
+	}, repoPath)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pkgs, func(i, j int) bool { return pkgs[i].ImportPath < pkgs[j].ImportPath })
+	return pkgs, nil
+}
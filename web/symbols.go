@@ -0,0 +1,53 @@
+package web
+
+import "github.com/p1rallels/codemapper/walker"
+
+// symbol is a flattened, addressable view over one parsed Go symbol. It
+// backs /api/symbol/{id} lookups and the /search endpoint, both of which
+// need a single ID space across types, interfaces, functions, and methods.
+type symbol struct {
+	ID      string
+	Kind    string // "type", "interface", "func", or "method"
+	Name    string
+	Package string
+	File    string
+	Detail  string
+}
+
+func indexSymbols(pkgs []*walker.GoPackage) map[string]*symbol {
+	idx := make(map[string]*symbol)
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			for _, t := range f.Types {
+				idx[symbolID(pkg, t.Name)] = &symbol{
+					ID: symbolID(pkg, t.Name), Kind: "type", Name: t.Name,
+					Package: pkg.ImportPath, File: f.Path,
+				}
+			}
+			for _, iface := range f.Interfaces {
+				idx[symbolID(pkg, iface.Name)] = &symbol{
+					ID: symbolID(pkg, iface.Name), Kind: "interface", Name: iface.Name,
+					Package: pkg.ImportPath, File: f.Path,
+				}
+			}
+			for _, fn := range f.Funcs {
+				idx[symbolID(pkg, fn.Name)] = &symbol{
+					ID: symbolID(pkg, fn.Name), Kind: "func", Name: fn.Name,
+					Package: pkg.ImportPath, File: f.Path, Detail: fn.Signature,
+				}
+			}
+			for _, m := range f.Methods {
+				name := m.Receiver + "." + m.Name
+				idx[symbolID(pkg, name)] = &symbol{
+					ID: symbolID(pkg, name), Kind: "method", Name: name,
+					Package: pkg.ImportPath, File: f.Path, Detail: m.Signature,
+				}
+			}
+		}
+	}
+	return idx
+}
+
+func symbolID(pkg *walker.GoPackage, name string) string {
+	return pkg.ImportPath + "#" + name
+}